@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// CircularBuffer represents a fixed-size circular buffer holding values of
+// any type. Once full, each Push overwrites the oldest element, unless a
+// compressed overflow tier has been configured via WithCompressedChunks, in
+// which case overwritten elements are retained there instead.
+type CircularBuffer[T any] struct {
+	buffer []T          // The underlying slice to store the data
+	size   int          // The fixed size of the buffer
+	head   int          // Index where the next element will be inserted
+	tail   int          // Index of the oldest element
+	count  int          // Number of elements currently in the buffer
+	mutex  sync.RWMutex // Mutex for thread-safety
+
+	written  uint64          // total number of elements ever pushed
+	compress *compression[T] // optional compressed overflow tier, nil if unused
+
+	policy    OverflowPolicy // behavior of Push once the buffer is full
+	notFullCh chan struct{}  // closed and replaced whenever Pop frees a slot, waking every blocked PushContext
+
+	// onInsert and onEvict are optional package-private hooks fired under
+	// cb.mutex whenever an item enters or permanently leaves the live
+	// window, letting NumericBuffer maintain incremental aggregates
+	// without rescanning the buffer.
+	onInsert func(item T, n int) // n is the count after insertion
+	onEvict  func(item T, n int) // n is the count before eviction
+
+	subscribers []*subscriber[T] // channels registered via Subscribe/SubscribeWithReplay
+}
+
+// Option configures a CircularBuffer at construction time.
+type Option[T any] func(*CircularBuffer[T])
+
+// WithCompressedChunks enables the compressed overflow tier: once the
+// primary ring fills, the oldest chunkSize elements are evicted together,
+// compressed with codec, and kept in a FIFO of at most keepChunks chunks.
+// A nil codec defaults to the standard library's DEFLATE implementation.
+func WithCompressedChunks[T any](chunkSize, keepChunks int, codec Codec) Option[T] {
+	return func(cb *CircularBuffer[T]) {
+		if codec == nil {
+			codec = newFlateCodec()
+		}
+		cb.compress = &compression[T]{
+			chunkSize:  chunkSize,
+			keepChunks: keepChunks,
+			codec:      codec,
+		}
+	}
+}
+
+// NewCircularBuffer creates and returns a new CircularBuffer with the specified size.
+func NewCircularBuffer[T any](size int, opts ...Option[T]) *CircularBuffer[T] {
+	cb := &CircularBuffer[T]{
+		buffer:    make([]T, size),
+		size:      size,
+		notFullCh: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(cb)
+	}
+	return cb
+}
+
+// Push adds a new item to the buffer and returns the item that was removed
+// (if any). Once the buffer is full, its behavior follows the configured
+// OverflowPolicy (WithOverflowPolicy); under PolicyBlock this blocks until a
+// Pop frees a slot, equivalent to PushContext(context.Background(), item).
+func (cb *CircularBuffer[T]) Push(item T) (T, error) {
+	return cb.PushContext(context.Background(), item)
+}
+
+// PushContext behaves like Push, except that under PolicyBlock it returns
+// ctx.Err() if ctx is done before a slot becomes available.
+func (cb *CircularBuffer[T]) PushContext(ctx context.Context, item T) (T, error) {
+	var zero T
+
+	cb.mutex.Lock()
+
+	if cb.count == cb.size {
+		switch cb.policy {
+		case PolicyReject:
+			cb.mutex.Unlock()
+			return zero, ErrFull
+		case PolicyBlock:
+			// WithCompressedChunks absorbs every overflowing element into
+			// the compressed tier instead of discarding it, so the ring
+			// never actually frees a slot on its own: count stays pinned
+			// at size forever and nothing would ever wake this wait.
+			// Compression takes priority over blocking in that case.
+			if cb.compress == nil {
+				if err := cb.waitForSlot(ctx); err != nil {
+					return zero, err
+				}
+			}
+		}
+	}
+	defer cb.mutex.Unlock()
+
+	return cb.pushLocked(item)
+}
+
+// waitForSlot blocks until the buffer has room for another element or ctx
+// is done. The caller must hold cb.mutex; on success it is re-acquired and
+// held on return. On error, cb.mutex is left unlocked.
+func (cb *CircularBuffer[T]) waitForSlot(ctx context.Context) error {
+	for cb.count == cb.size {
+		ch := cb.notFullCh
+		cb.mutex.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+		cb.mutex.Lock()
+	}
+	return nil
+}
+
+// pushLocked performs the actual insertion. The caller must hold cb.mutex.
+// A non-nil error means the compressed tier's integrity check rejected the
+// chunk the evicted item just went into (see compression.evict); the item
+// has already left the ring either way, so this is reported for visibility
+// rather than as a reason to abort the push.
+func (cb *CircularBuffer[T]) pushLocked(item T) (T, error) {
+	var poppedItem T
+	var err error
+
+	if cb.count == cb.size {
+		// Buffer is full, remove the oldest item
+		evicted := cb.buffer[cb.tail]
+		cb.tail = (cb.tail + 1) % cb.size
+
+		if cb.onEvict != nil {
+			cb.onEvict(evicted, cb.count)
+		}
+
+		if cb.compress != nil {
+			// Retained in the compressed tier rather than discarded.
+			err = cb.compress.evict(evicted)
+		} else {
+			poppedItem = evicted
+		}
+	} else {
+		// Buffer is not full, increase the count
+		cb.count++
+	}
+
+	// Add the new item at the head
+	cb.buffer[cb.head] = item
+	// Move the head forward, wrapping around if necessary
+	cb.head = (cb.head + 1) % cb.size
+	cb.written++
+
+	if cb.onInsert != nil {
+		cb.onInsert(item, cb.count)
+	}
+	cb.broadcast(item)
+
+	return poppedItem, err
+}
+
+// notifyNotFull wakes every blocked PushContext call, if any. The caller
+// must hold cb.mutex. A buffered 1-slot channel would collapse back-to-back
+// notifications into one, stranding a second waiter forever even though a
+// slot is free; closing the channel instead wakes every current waiter, and
+// swapping in a fresh one means the close can never be missed or reused.
+func (cb *CircularBuffer[T]) notifyNotFull() {
+	close(cb.notFullCh)
+	cb.notFullCh = make(chan struct{})
+}
+
+// Pop removes and returns the oldest item from the buffer.
+// The boolean return value indicates whether an item was successfully removed.
+func (cb *CircularBuffer[T]) Pop() (T, bool) {
+	cb.mutex.Lock() // Write lock
+	defer cb.mutex.Unlock()
+
+	// The compressed tier, if any, holds older elements than the ring.
+	if cb.compress != nil && !cb.compress.empty() {
+		return cb.compress.popOldest()
+	}
+
+	if cb.count == 0 {
+		// Buffer is empty
+		var zero T
+		return zero, false
+	}
+
+	item := cb.buffer[cb.tail]
+	// Move the tail forward, wrapping around if necessary
+	cb.tail = (cb.tail + 1) % cb.size
+
+	if cb.onEvict != nil {
+		cb.onEvict(item, cb.count)
+	}
+
+	cb.count--
+	cb.notifyNotFull()
+
+	return item, true
+}
+
+// Len returns the number of elements currently in the buffer.
+func (cb *CircularBuffer[T]) Len() int {
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+
+	return cb.count
+}
+
+// Items returns a copy of everything still retrievable, oldest first: the
+// compressed overflow tier (if any), followed by the live ring from tail to
+// head. This is the same ordering Pop drains in, so iterating it mirrors
+// repeated Pop calls without consuming the buffer.
+func (cb *CircularBuffer[T]) Items() []T {
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+
+	var items []T
+	if cb.compress != nil {
+		items = cb.compress.items()
+	}
+
+	return append(items, cb.ringItemsLocked()...)
+}
+
+// ringItemsLocked returns a copy of just the live ring, oldest first,
+// excluding anything sitting in the compressed overflow tier. The caller
+// must hold cb.mutex (for reading or writing).
+func (cb *CircularBuffer[T]) ringItemsLocked() []T {
+	items := make([]T, cb.count)
+	for i := 0; i < cb.count; i++ {
+		// Calculate the actual index, wrapping around if necessary
+		items[i] = cb.buffer[(cb.tail+i)%cb.size]
+	}
+	return items
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrTruncated is returned by Reader.Read and ByteBuffer.ReadAt when the
+// requested offset has already been overwritten by newer writes.
+var ErrTruncated = errors.New("circularbuffer: read offset has been overwritten")
+
+// ByteBuffer is a fixed-size circular buffer of bytes. Writes overwrite the
+// oldest bytes once the buffer is full, and reads are addressed by absolute
+// write offset so independent Readers can each track their own position.
+type ByteBuffer struct {
+	buf      []byte
+	size     int
+	writeOff int64 // total bytes ever written
+	readOff  int64 // cursor used by Read
+	mutex    sync.RWMutex
+}
+
+// NewByteBuffer creates and returns a new ByteBuffer with the specified capacity.
+func NewByteBuffer(size int) *ByteBuffer {
+	return &ByteBuffer{
+		buf:  make([]byte, size),
+		size: size,
+	}
+}
+
+// Write appends p to the buffer, overwriting the oldest bytes if p does not
+// fit in the remaining capacity. It always consumes all of p.
+func (bb *ByteBuffer) Write(p []byte) (int, error) {
+	bb.mutex.Lock()
+	defer bb.mutex.Unlock()
+
+	n := len(p)
+	if n == 0 {
+		return 0, nil
+	}
+
+	// If p alone is larger than the buffer, only its tail survives.
+	if n > bb.size {
+		p = p[n-bb.size:]
+	}
+
+	start := int(bb.writeOff % int64(bb.size))
+	copied := copy(bb.buf[start:], p)
+	if copied < len(p) {
+		copy(bb.buf, p[copied:])
+	}
+
+	bb.writeOff += int64(n)
+	return n, nil
+}
+
+// available reports the range of absolute offsets [oldest, writeOff) still
+// held in the buffer. The caller must hold bb.mutex.
+func (bb *ByteBuffer) available() (oldest, newest int64) {
+	newest = bb.writeOff
+	oldest = newest - int64(bb.size)
+	if oldest < 0 {
+		oldest = 0
+	}
+	return oldest, newest
+}
+
+// ReadAt reads up to len(p) bytes starting at absolute offset off, following
+// the io.ReaderAt contract: it returns ErrTruncated if off is older than the
+// oldest byte still retained, and io.EOF whenever it returns fewer than
+// len(p) bytes because off has caught up to the write edge, including the
+// zero-byte case where nothing has been written at off yet.
+func (bb *ByteBuffer) ReadAt(p []byte, off int64) (int, error) {
+	bb.mutex.RLock()
+	defer bb.mutex.RUnlock()
+
+	oldest, newest := bb.available()
+	if off < oldest {
+		return 0, ErrTruncated
+	}
+	if off >= newest {
+		return 0, io.EOF
+	}
+
+	n := len(p)
+	var err error
+	if remaining := int(newest - off); n > remaining {
+		n = remaining
+		err = io.EOF
+	}
+
+	start := int(off % int64(bb.size))
+	copied := copy(p[:n], bb.buf[start:])
+	if copied < n {
+		copy(p[copied:n], bb.buf)
+	}
+
+	return n, err
+}
+
+// Read reads from the buffer's own internal cursor, which starts at the
+// oldest retained byte. Most callers should prefer NewReader, which gives
+// each consumer an independent cursor. Unlike ReadAt, Read never returns
+// io.EOF for having caught up to the write edge: more bytes may still
+// arrive later, so callers should poll rather than treat this as the end of
+// the stream.
+func (bb *ByteBuffer) Read(p []byte) (int, error) {
+	bb.mutex.Lock()
+	oldest := bb.readOffLocked()
+	bb.mutex.Unlock()
+
+	n, err := bb.ReadAt(p, oldest)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	bb.mutex.Lock()
+	bb.readOff = oldest + int64(n)
+	bb.mutex.Unlock()
+
+	return n, nil
+}
+
+// readOffLocked returns the buffer's internal read cursor, clamped forward
+// if it has fallen behind the oldest retained byte. The caller must hold bb.mutex.
+func (bb *ByteBuffer) readOffLocked() int64 {
+	oldest, _ := bb.available()
+	if bb.readOff < oldest {
+		return oldest
+	}
+	return bb.readOff
+}
+
+// Reader is an independent cursor over a ByteBuffer's write history.
+type Reader struct {
+	bb  *ByteBuffer
+	off int64
+}
+
+// NewReader returns a Reader starting at the oldest byte currently retained
+// in the buffer, with its own cursor independent of any other Reader.
+func (bb *ByteBuffer) NewReader() *Reader {
+	bb.mutex.RLock()
+	defer bb.mutex.RUnlock()
+
+	oldest, _ := bb.available()
+	return &Reader{bb: bb, off: oldest}
+}
+
+// Read reads the next available bytes from the reader's cursor. If no new
+// data has been written since the last Read, it returns (0, nil); callers
+// should poll. If the cursor has fallen behind and its data has been
+// overwritten, it returns ErrTruncated.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.bb.ReadAt(p, r.off)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	r.off += int64(n)
+	return n, nil
+}
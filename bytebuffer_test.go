@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestByteBufferWriteWraparound(t *testing.T) {
+	bb := NewByteBuffer(4)
+	bb.Write([]byte{1, 2, 3})
+	bb.Write([]byte{4, 5, 6})
+
+	got := make([]byte, 4)
+	n, err := bb.ReadAt(got, 2)
+	if err != nil {
+		t.Fatalf("ReadAt: unexpected err: %v", err)
+	}
+	want := []byte{3, 4, 5, 6}
+	if n != len(want) {
+		t.Fatalf("n = %d, want %d", n, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ReadAt = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestByteBufferReadAtErrTruncated(t *testing.T) {
+	bb := NewByteBuffer(4)
+	bb.Write([]byte{1, 2, 3, 4, 5, 6})
+
+	p := make([]byte, 2)
+	if _, err := bb.ReadAt(p, 0); !errors.Is(err, ErrTruncated) {
+		t.Fatalf("ReadAt at overwritten offset: err = %v, want ErrTruncated", err)
+	}
+}
+
+func TestByteBufferReadAtShortReadReturnsEOF(t *testing.T) {
+	bb := NewByteBuffer(8)
+	bb.Write([]byte{1, 2, 3})
+
+	p := make([]byte, 10)
+	n, err := bb.ReadAt(p, 0)
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadAt short read: err = %v, want io.EOF", err)
+	}
+	if n != 3 {
+		t.Fatalf("n = %d, want 3", n)
+	}
+}
+
+func TestByteBufferReadAtNotYetWrittenReturnsEOF(t *testing.T) {
+	bb := NewByteBuffer(8)
+
+	n, err := bb.ReadAt(make([]byte, 4), 0)
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadAt on empty buffer: err = %v, want io.EOF", err)
+	}
+	if n != 0 {
+		t.Fatalf("n = %d, want 0", n)
+	}
+}
+
+func TestByteBufferReadPolls(t *testing.T) {
+	bb := NewByteBuffer(8)
+	bb.Write([]byte{1, 2, 3})
+
+	p := make([]byte, 8)
+	n, err := bb.Read(p)
+	if err != nil {
+		t.Fatalf("Read: unexpected err: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("n = %d, want 3", n)
+	}
+
+	// Nothing new has been written: Read should report (0, nil), not io.EOF,
+	// since a poller expects more data may still arrive later.
+	n, err = bb.Read(p)
+	if err != nil {
+		t.Fatalf("Read after catching up: unexpected err: %v", err)
+	}
+	if n != 0 {
+		t.Fatalf("n = %d, want 0", n)
+	}
+}
+
+func TestReaderIndependentCursors(t *testing.T) {
+	bb := NewByteBuffer(8)
+	bb.Write([]byte{1, 2, 3})
+
+	r1 := bb.NewReader()
+	p1 := make([]byte, 2)
+	if n, err := r1.Read(p1); err != nil || n != 2 {
+		t.Fatalf("r1.Read = (%d, %v), want (2, nil)", n, err)
+	}
+
+	bb.Write([]byte{4, 5})
+
+	r2 := bb.NewReader()
+	p2 := make([]byte, 8)
+	n2, err := r2.Read(p2)
+	if err != nil {
+		t.Fatalf("r2.Read: unexpected err: %v", err)
+	}
+	want2 := []byte{1, 2, 3, 4, 5}
+	if n2 != len(want2) {
+		t.Fatalf("r2 read %d bytes, want %d", n2, len(want2))
+	}
+	for i := range want2 {
+		if p2[i] != want2[i] {
+			t.Fatalf("r2 read = %v, want %v", p2[:n2], want2)
+		}
+	}
+
+	// r1's cursor should be unaffected by r2's reads.
+	p1b := make([]byte, 8)
+	n1, err := r1.Read(p1b)
+	if err != nil {
+		t.Fatalf("r1.Read (2nd): unexpected err: %v", err)
+	}
+	want1 := []byte{3, 4, 5}
+	if n1 != len(want1) {
+		t.Fatalf("r1 read %d bytes, want %d", n1, len(want1))
+	}
+	for i := range want1 {
+		if p1b[i] != want1[i] {
+			t.Fatalf("r1 read = %v, want %v", p1b[:n1], want1)
+		}
+	}
+}
+
+func TestReaderReturnsErrTruncated(t *testing.T) {
+	bb := NewByteBuffer(4)
+	r := bb.NewReader()
+
+	bb.Write([]byte{1, 2, 3, 4, 5, 6})
+
+	if _, err := r.Read(make([]byte, 2)); !errors.Is(err, ErrTruncated) {
+		t.Fatalf("Read after being overwritten: err = %v, want ErrTruncated", err)
+	}
+}
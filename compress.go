@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// Codec compresses and decompresses the raw bytes of an evicted chunk.
+// Implementations must be safe for concurrent use.
+type Codec interface {
+	Compress([]byte) []byte
+	Decompress([]byte) ([]byte, error)
+}
+
+// flateCodec is the default Codec, backed by the standard library's
+// DEFLATE implementation. It requires no external dependencies.
+type flateCodec struct{}
+
+func newFlateCodec() Codec {
+	return flateCodec{}
+}
+
+func (flateCodec) Compress(data []byte) []byte {
+	var out bytes.Buffer
+	w, _ := flate.NewWriter(&out, flate.DefaultCompression)
+	_, _ = w.Write(data)
+	_ = w.Close()
+	return out.Bytes()
+}
+
+func (flateCodec) Decompress(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// compression holds the compressed overflow tier for a CircularBuffer. Once
+// the primary ring fills, evicted elements accumulate in pending until there
+// are enough to fill a chunk, at which point the chunk is encoded and
+// compressed into the chunks FIFO. At most keepChunks compressed chunks are
+// retained; older ones are discarded permanently.
+type compression[T any] struct {
+	chunkSize  int
+	keepChunks int
+	codec      Codec
+
+	pending []T      // items waiting to form the next full chunk
+	chunks  [][]byte // FIFO of compressed chunks, oldest first
+	decoded []T      // residual from the oldest chunk, decompressed on demand
+
+	scratch bytes.Buffer // reused on the write path to keep evict allocation-light
+}
+
+// evict absorbs an item overwritten by the primary ring into the compressed
+// tier, compressing a chunk once pending fills up. A non-nil error means
+// gob could not faithfully round-trip the chunk (see verifyGobRoundTrip);
+// the chunk is still stored in that case, since a lossy copy of data that
+// has already left the ring beats losing it outright, but the caller is
+// told so it can stop relying on T's unexported fields surviving here.
+func (c *compression[T]) evict(item T) error {
+	c.pending = append(c.pending, item)
+	if len(c.pending) < c.chunkSize {
+		return nil
+	}
+
+	c.scratch.Reset()
+	if err := gob.NewEncoder(&c.scratch).Encode(c.pending); err != nil {
+		// Encoding a slice of a concrete type cannot fail; drop the chunk
+		// rather than panic if it somehow does.
+		c.pending = c.pending[:0]
+		return fmt.Errorf("circularbuffer: encode compressed chunk: %w", err)
+	}
+	encoded := append([]byte(nil), c.scratch.Bytes()...)
+
+	verifyErr := verifyGobRoundTrip(c.pending, encoded)
+
+	c.chunks = append(c.chunks, c.codec.Compress(encoded))
+	c.pending = c.pending[:0]
+
+	if len(c.chunks) > c.keepChunks {
+		c.chunks = c.chunks[1:]
+	}
+
+	return verifyErr
+}
+
+// empty reports whether the compressed tier holds no retrievable items.
+func (c *compression[T]) empty() bool {
+	return len(c.decoded) == 0 && len(c.chunks) == 0
+}
+
+// items returns a non-destructive, oldest-first copy of everything held in
+// the compressed tier: any already-decoded residual, then each remaining
+// compressed chunk in order, then the not-yet-full pending chunk. Chunks
+// that fail to decompress or decode are skipped rather than panicking.
+func (c *compression[T]) items() []T {
+	var out []T
+	out = append(out, c.decoded...)
+
+	for _, chunk := range c.chunks {
+		raw, err := c.codec.Decompress(chunk)
+		if err != nil {
+			continue
+		}
+
+		var decoded []T
+		if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&decoded); err != nil {
+			continue
+		}
+		out = append(out, decoded...)
+	}
+
+	out = append(out, c.pending...)
+	return out
+}
+
+// popOldest removes and returns the oldest item held in the compressed tier,
+// decompressing the next chunk on demand with its own scratch buffer so
+// concurrent readers never contend over decompression state.
+func (c *compression[T]) popOldest() (T, bool) {
+	if len(c.decoded) == 0 {
+		if len(c.chunks) == 0 {
+			var zero T
+			return zero, false
+		}
+
+		raw, err := c.codec.Decompress(c.chunks[0])
+		c.chunks = c.chunks[1:]
+		if err != nil {
+			var zero T
+			return zero, false
+		}
+
+		var items []T
+		if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&items); err != nil {
+			var zero T
+			return zero, false
+		}
+		c.decoded = items
+	}
+
+	item := c.decoded[0]
+	c.decoded = c.decoded[1:]
+	return item, true
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompressedTierWithBlockPolicyDoesNotDeadlock(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cb := NewCircularBuffer[int](2, WithCompressedChunks[int](2, 5, nil), WithOverflowPolicy[int](PolicyBlock))
+		for i := 0; i < 8; i++ {
+			if _, err := cb.Push(i); err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Push deadlocked: WithCompressedChunks combined with PolicyBlock never unblocked")
+	}
+}
+
+func TestCompressedTierRoundTrip(t *testing.T) {
+	cb := NewCircularBuffer[int](2, WithCompressedChunks[int](2, 5, nil))
+	for i := 0; i < 6; i++ {
+		if _, err := cb.Push(i); err != nil {
+			t.Fatalf("Push(%d): unexpected err: %v", i, err)
+		}
+	}
+
+	var got []int
+	for {
+		v, ok := cb.Pop()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+	}
+
+	want := []int{0, 1, 2, 3, 4, 5}
+	if len(got) != len(want) {
+		t.Fatalf("Pop sequence = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Pop sequence = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestItemsIncludesCompressedTier(t *testing.T) {
+	cb := NewCircularBuffer[int](2, WithCompressedChunks[int](2, 5, nil))
+	for i := 0; i < 6; i++ {
+		cb.Push(i)
+	}
+
+	got := cb.Items()
+	want := []int{0, 1, 2, 3, 4, 5}
+	if len(got) != len(want) {
+		t.Fatalf("Items() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Items() = %v, want %v", got, want)
+		}
+	}
+}
+
+type compressEvent struct {
+	Name  string
+	count int // unexported: gob cannot round-trip this
+}
+
+func TestCompressedChunkReportsUnexportedFieldLoss(t *testing.T) {
+	cb := NewCircularBuffer[compressEvent](1, WithCompressedChunks[compressEvent](1, 3, nil))
+	cb.Push(compressEvent{Name: "a", count: 1})
+
+	_, err := cb.Push(compressEvent{Name: "b", count: 2})
+	if err == nil {
+		t.Fatal("expected Push to surface the compressed-chunk gob round-trip error")
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+)
+
+// verifyGobRoundTrip reports an error if decoding encoded (the gob encoding
+// of original) does not reproduce original exactly. gob silently drops
+// unexported struct fields on encode without ever returning an error, so
+// comparing the actual decoded result against the original is the only
+// reliable way to catch that before it causes silent data loss. Both
+// SaveSnapshot and the compressed overflow tier call this right after
+// encoding, since both persist arbitrary generic T.
+func verifyGobRoundTrip[T any](original []T, encoded []byte) error {
+	var decoded []T
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&decoded); err != nil {
+		return fmt.Errorf("circularbuffer: gob round-trip decode failed: %w", err)
+	}
+
+	if !reflect.DeepEqual(original, decoded) {
+		return fmt.Errorf("circularbuffer: T has fields gob cannot round-trip faithfully " +
+			"(most likely unexported struct fields, which gob drops silently on encode) " +
+			"— only use exported-field types with snapshotting or compression, or implement " +
+			"encoding.BinaryMarshaler/BinaryUnmarshaler on T")
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Number is the set of numeric types that NumericBuffer can aggregate over.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// seqValue pairs a value with the monotonically increasing id assigned to
+// it when it was pushed, so the min/max deques know which entry expires
+// when the oldest item leaves the window.
+type seqValue[T Number] struct {
+	seq   int64
+	value T
+}
+
+// NumericBuffer wraps a CircularBuffer of numeric values and adds
+// statistical helpers that don't make sense for arbitrary payload types.
+// Sum, Average, Variance, StdDev, Min and Max are all maintained
+// incrementally as items are pushed and evicted, so reading them is O(1)
+// regardless of buffer size.
+type NumericBuffer[T Number] struct {
+	*CircularBuffer[T]
+
+	sum  T       // running sum of all items currently in the window
+	mean float64 // Welford's running mean
+	m2   float64 // Welford's running sum of squared differences from mean
+
+	seq          int64         // id assigned to the most recently pushed item
+	nextEvictSeq int64         // id of the next item expected to leave the window
+	minDeque     []seqValue[T] // monotonically increasing: front holds the current min
+	maxDeque     []seqValue[T] // monotonically decreasing: front holds the current max
+}
+
+// NewNumericBuffer creates and returns a new NumericBuffer with the specified size.
+func NewNumericBuffer[T Number](size int, opts ...Option[T]) *NumericBuffer[T] {
+	nb := &NumericBuffer[T]{
+		CircularBuffer: NewCircularBuffer[T](size, opts...),
+		nextEvictSeq:   1,
+	}
+	nb.onInsert = nb.insert
+	nb.onEvict = nb.evict
+	return nb
+}
+
+// insert updates the running aggregates for an item just added to the
+// window, which now holds n items. It runs under cb.mutex.
+func (nb *NumericBuffer[T]) insert(item T, n int) {
+	nb.sum += item
+
+	x := float64(item)
+	if n == 1 {
+		nb.mean = x
+		nb.m2 = 0
+	} else {
+		delta := x - nb.mean
+		nb.mean += delta / float64(n)
+		nb.m2 += delta * (x - nb.mean)
+	}
+
+	nb.seq++
+	nb.minDeque = pushMonotonic(nb.minDeque, nb.seq, item, func(a, b T) bool { return a >= b })
+	nb.maxDeque = pushMonotonic(nb.maxDeque, nb.seq, item, func(a, b T) bool { return a <= b })
+}
+
+// evict updates the running aggregates for an item about to permanently
+// leave the window, which currently holds n items (including it). It runs
+// under cb.mutex. This is the reverse of insert's Welford update: it backs
+// mean and M2 out to what they would have been without this item.
+func (nb *NumericBuffer[T]) evict(item T, n int) {
+	nb.sum -= item
+
+	if n <= 1 {
+		nb.mean = 0
+		nb.m2 = 0
+	} else {
+		x := float64(item)
+		meanOld := nb.mean
+		nb.mean = (nb.mean*float64(n) - x) / float64(n-1)
+		nb.m2 -= (x - meanOld) * (x - nb.mean)
+	}
+
+	nb.minDeque = popExpired(nb.minDeque, nb.nextEvictSeq)
+	nb.maxDeque = popExpired(nb.maxDeque, nb.nextEvictSeq)
+	nb.nextEvictSeq++
+}
+
+// pushMonotonic appends (seq, value) to a deque, first dropping trailing
+// entries that evict(prune) reports can never again be the extreme value
+// before value itself leaves the window. evict(a, b) should report whether
+// a can be pruned in favor of b (e.g. a >= b for a min-tracking deque).
+func pushMonotonic[T Number](deque []seqValue[T], seq int64, value T, prune func(a, b T) bool) []seqValue[T] {
+	for len(deque) > 0 && prune(deque[len(deque)-1].value, value) {
+		deque = deque[:len(deque)-1]
+	}
+	return append(deque, seqValue[T]{seq: seq, value: value})
+}
+
+// popExpired drops the front of a deque if it corresponds to the item with
+// the given seq, which is now leaving the window.
+func popExpired[T Number](deque []seqValue[T], expiredSeq int64) []seqValue[T] {
+	if len(deque) > 0 && deque[0].seq == expiredSeq {
+		return deque[1:]
+	}
+	return deque
+}
+
+// Sum returns the sum of all items currently in the buffer.
+func (nb *NumericBuffer[T]) Sum() T {
+	nb.mutex.RLock()
+	defer nb.mutex.RUnlock()
+
+	return nb.sum
+}
+
+// Average calculates and returns the average of all items in the buffer.
+func (nb *NumericBuffer[T]) Average() float64 {
+	nb.mutex.RLock()
+	defer nb.mutex.RUnlock()
+
+	if nb.count == 0 {
+		return 0
+	}
+
+	return float64(nb.sum) / float64(nb.count)
+}
+
+// Variance calculates and returns the population variance of all items in the buffer.
+func (nb *NumericBuffer[T]) Variance() float64 {
+	nb.mutex.RLock()
+	defer nb.mutex.RUnlock()
+
+	if nb.count == 0 {
+		return 0
+	}
+
+	return nb.m2 / float64(nb.count)
+}
+
+// StdDev calculates and returns the population standard deviation of all items in the buffer.
+func (nb *NumericBuffer[T]) StdDev() float64 {
+	return math.Sqrt(nb.Variance())
+}
+
+// Min returns the smallest item currently in the buffer.
+func (nb *NumericBuffer[T]) Min() (T, bool) {
+	nb.mutex.RLock()
+	defer nb.mutex.RUnlock()
+
+	if len(nb.minDeque) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return nb.minDeque[0].value, true
+}
+
+// Max returns the largest item currently in the buffer.
+func (nb *NumericBuffer[T]) Max() (T, bool) {
+	nb.mutex.RLock()
+	defer nb.mutex.RUnlock()
+
+	if len(nb.maxDeque) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return nb.maxDeque[0].value, true
+}
+
+// Percentile returns the p-th percentile (0-100) of the items currently in
+// the buffer, linearly interpolating between the closest ranks. Unlike the
+// statistics above this is O(n log n): percentiles can't be maintained
+// incrementally without a more specialized structure such as a t-digest. It
+// is computed over the same live-ring window as Sum/Average/Variance/Min/Max
+// rather than Items(), so that with WithCompressedChunks in use it describes
+// the same data those aggregates do instead of a wider one that also
+// includes the compressed tier.
+func (nb *NumericBuffer[T]) Percentile(p float64) float64 {
+	nb.mutex.RLock()
+	items := nb.ringItemsLocked()
+	nb.mutex.RUnlock()
+
+	if len(items) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(items))
+	for i, v := range items {
+		sorted[i] = float64(v)
+	}
+	sort.Float64s(sorted)
+
+	switch {
+	case p <= 0:
+		return sorted[0]
+	case p >= 100:
+		return sorted[len(sorted)-1]
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return sorted[lo]
+	}
+
+	frac := rank - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac
+}
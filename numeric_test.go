@@ -0,0 +1,178 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// bruteForceStats recomputes sum/average/variance/min/max by rescanning,
+// the reference the incremental NumericBuffer implementation is checked
+// against.
+func bruteForceStats(window []float64) (sum, avg, variance, min, max float64) {
+	min, max = window[0], window[0]
+	for _, v := range window {
+		sum += v
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	avg = sum / float64(len(window))
+	for _, v := range window {
+		d := v - avg
+		variance += d * d
+	}
+	variance /= float64(len(window))
+	return sum, avg, variance, min, max
+}
+
+func TestNumericBufferIncrementalStatsAgainstBruteForce(t *testing.T) {
+	const size = 10
+	nb := NewNumericBuffer[float64](size)
+	r := rand.New(rand.NewSource(7))
+
+	var window []float64
+	for i := 0; i < 1000; i++ {
+		v := r.Float64()*200 - 100
+		nb.Push(v)
+
+		window = append(window, v)
+		if len(window) > size {
+			window = window[1:]
+		}
+
+		wantSum, wantAvg, wantVar, wantMin, wantMax := bruteForceStats(window)
+
+		if got := nb.Sum(); math.Abs(got-wantSum) > 1e-6 {
+			t.Fatalf("iter %d: Sum = %v, want %v", i, got, wantSum)
+		}
+		if got := nb.Average(); math.Abs(got-wantAvg) > 1e-6 {
+			t.Fatalf("iter %d: Average = %v, want %v", i, got, wantAvg)
+		}
+		if got := nb.Variance(); math.Abs(got-wantVar) > 1e-6 {
+			t.Fatalf("iter %d: Variance = %v, want %v", i, got, wantVar)
+		}
+		if got, _ := nb.Min(); math.Abs(got-wantMin) > 1e-9 {
+			t.Fatalf("iter %d: Min = %v, want %v", i, got, wantMin)
+		}
+		if got, _ := nb.Max(); math.Abs(got-wantMax) > 1e-9 {
+			t.Fatalf("iter %d: Max = %v, want %v", i, got, wantMax)
+		}
+	}
+}
+
+func TestNumericBufferStatsTrackPop(t *testing.T) {
+	nb := NewNumericBuffer[float64](5)
+	for _, v := range []float64{3, 1, 4, 1, 5} {
+		nb.Push(v)
+	}
+
+	nb.Pop() // removes 3
+
+	window := []float64{1, 4, 1, 5}
+	wantSum, wantAvg, wantVar, wantMin, wantMax := bruteForceStats(window)
+
+	if got := nb.Sum(); math.Abs(got-wantSum) > 1e-9 {
+		t.Fatalf("Sum = %v, want %v", got, wantSum)
+	}
+	if got := nb.Average(); math.Abs(got-wantAvg) > 1e-9 {
+		t.Fatalf("Average = %v, want %v", got, wantAvg)
+	}
+	if got := nb.Variance(); math.Abs(got-wantVar) > 1e-9 {
+		t.Fatalf("Variance = %v, want %v", got, wantVar)
+	}
+	if got, _ := nb.Min(); got != wantMin {
+		t.Fatalf("Min = %v, want %v", got, wantMin)
+	}
+	if got, _ := nb.Max(); got != wantMax {
+		t.Fatalf("Max = %v, want %v", got, wantMax)
+	}
+}
+
+func TestNumericBufferPercentile(t *testing.T) {
+	nb := NewNumericBuffer[float64](5)
+	for _, v := range []float64{5, 1, 4, 2, 3} {
+		nb.Push(v)
+	}
+
+	sorted := []float64{1, 2, 3, 4, 5}
+	if got := nb.Percentile(0); got != sorted[0] {
+		t.Fatalf("Percentile(0) = %v, want %v", got, sorted[0])
+	}
+	if got := nb.Percentile(100); got != sorted[len(sorted)-1] {
+		t.Fatalf("Percentile(100) = %v, want %v", got, sorted[len(sorted)-1])
+	}
+	if got := nb.Percentile(50); got != 3 {
+		t.Fatalf("Percentile(50) = %v, want 3", got)
+	}
+}
+
+func TestNumericBufferPercentileMatchesStatsWindowWithCompression(t *testing.T) {
+	nb := NewNumericBuffer[float64](2, WithCompressedChunks[float64](2, 5, nil))
+	for _, v := range []float64{1, 2, 3, 4} {
+		nb.Push(v)
+	}
+
+	// The live ring only holds {3, 4}; {1, 2} has been absorbed into the
+	// compressed tier. Percentile must describe that same ring-only window,
+	// not Items()'s ring-plus-compressed-tier view, or it disagrees with
+	// Average/Min/Max about what "the buffer" currently contains.
+	if got := nb.Average(); got != 3.5 {
+		t.Fatalf("Average = %v, want 3.5", got)
+	}
+	if got := nb.Percentile(50); got != 3.5 {
+		t.Fatalf("Percentile(50) = %v, want 3.5 (same window as Average)", got)
+	}
+}
+
+func TestNumericBufferEmpty(t *testing.T) {
+	nb := NewNumericBuffer[float64](3)
+
+	if got := nb.Average(); got != 0 {
+		t.Fatalf("Average on empty buffer = %v, want 0", got)
+	}
+	if got := nb.Variance(); got != 0 {
+		t.Fatalf("Variance on empty buffer = %v, want 0", got)
+	}
+	if _, ok := nb.Min(); ok {
+		t.Fatal("Min on empty buffer should report ok=false")
+	}
+	if _, ok := nb.Max(); ok {
+		t.Fatal("Max on empty buffer should report ok=false")
+	}
+}
+
+func TestPushMonotonicMatchesBruteForceMin(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	var deque []seqValue[int]
+	var values []int
+
+	for i := 0; i < 200; i++ {
+		v := r.Intn(50)
+		values = append(values, v)
+		deque = pushMonotonic(deque, int64(i), v, func(a, b int) bool { return a >= b })
+
+		window := values
+		if len(window) > 10 {
+			window = window[len(window)-10:]
+			// Expire anything in the deque older than the window.
+			oldestSeq := int64(len(values) - 10)
+			for len(deque) > 0 && deque[0].seq < oldestSeq {
+				deque = deque[1:]
+			}
+		}
+
+		want := window[0]
+		sorted := append([]int(nil), window...)
+		sort.Ints(sorted)
+		want = sorted[0]
+
+		if len(deque) == 0 || deque[0].value != want {
+			t.Fatalf("iter %d: deque front = %+v, want min %d", i, deque, want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import "errors"
+
+// ErrFull is returned by Push and PushContext when the buffer is full and
+// its OverflowPolicy is PolicyReject.
+var ErrFull = errors.New("circularbuffer: buffer is full")
+
+// OverflowPolicy determines what Push does once the buffer is full.
+type OverflowPolicy int
+
+const (
+	// PolicyOverwrite discards the oldest element to make room (the
+	// default, and the original behavior of this package).
+	PolicyOverwrite OverflowPolicy = iota
+	// PolicyReject returns ErrFull without writing.
+	PolicyReject
+	// PolicyBlock blocks until a Pop frees a slot, or until the context
+	// passed to PushContext is done.
+	PolicyBlock
+)
+
+// WithOverflowPolicy sets the buffer's behavior once it is full.
+func WithOverflowPolicy[T any](policy OverflowPolicy) Option[T] {
+	return func(cb *CircularBuffer[T]) {
+		cb.policy = policy
+	}
+}
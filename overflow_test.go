@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPolicyRejectReturnsErrFullWithoutWriting(t *testing.T) {
+	cb := NewCircularBuffer[int](2, WithOverflowPolicy[int](PolicyReject))
+	cb.Push(1)
+	cb.Push(2)
+
+	if _, err := cb.Push(3); !errors.Is(err, ErrFull) {
+		t.Fatalf("Push on full PolicyReject buffer: err = %v, want ErrFull", err)
+	}
+
+	got := cb.Items()
+	want := []int{1, 2}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("Items() = %v, want %v (rejected push must not write)", got, want)
+	}
+}
+
+func TestPolicyBlockPushContextCancellation(t *testing.T) {
+	cb := NewCircularBuffer[int](1, WithOverflowPolicy[int](PolicyBlock))
+	cb.Push(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	if _, err := cb.PushContext(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("PushContext on full PolicyBlock buffer: err = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestPolicyBlockUnblocksOnPop(t *testing.T) {
+	cb := NewCircularBuffer[int](1, WithOverflowPolicy[int](PolicyBlock))
+	cb.Push(1)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cb.Push(2)
+		done <- err
+	}()
+
+	// Give the blocked Push a moment to actually start waiting.
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("Push returned before a slot freed up (err=%v)", err)
+	default:
+	}
+
+	cb.Pop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("blocked Push never unblocked after Pop freed a slot")
+	}
+
+	if got := cb.Items(); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("Items() = %v, want [2]", got)
+	}
+}
+
+func TestPolicyBlockWakesAllWaiters(t *testing.T) {
+	cb := NewCircularBuffer[int](1, WithOverflowPolicy[int](PolicyBlock))
+	cb.Push(1)
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		v := i + 2
+		go func() {
+			_, err := cb.Push(v)
+			done <- err
+		}()
+	}
+
+	// Give both blocked Pushes a moment to actually start waiting.
+	time.Sleep(20 * time.Millisecond)
+
+	// Two Pops, each freeing a slot that's refilled by one of the waiters.
+	// With a collapsing single-slot notify, a second notify arriving before
+	// the first waiter drains the channel would be dropped, stranding the
+	// other waiter forever even though its slot is free.
+	cb.Pop()
+	time.Sleep(20 * time.Millisecond) // let one waiter refill the freed slot
+	cb.Pop()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("a blocked Push never unblocked despite two Pops freeing two slots")
+		}
+	}
+}
+
+func TestPolicyOverwriteIsDefault(t *testing.T) {
+	cb := NewCircularBuffer[int](2)
+	cb.Push(1)
+	cb.Push(2)
+
+	popped, err := cb.Push(3)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if popped != 1 {
+		t.Fatalf("popped = %v, want 1", popped)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// PersistentBuffer is a CircularBuffer that periodically flushes a snapshot
+// to disk and replays it on startup, so history survives a process restart.
+type PersistentBuffer[T any] struct {
+	*CircularBuffer[T]
+
+	path string
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewPersistentBuffer opens the snapshot at path (replaying it if present)
+// or creates a new buffer of the given size, and starts a background flush
+// every flushEvery that rewrites the snapshot at path.
+func NewPersistentBuffer[T any](path string, size int, flushEvery time.Duration) (*PersistentBuffer[T], error) {
+	cb, err := openOrCreate[T](path, size)
+	if err != nil {
+		return nil, err
+	}
+
+	pb := &PersistentBuffer[T]{
+		CircularBuffer: cb,
+		path:           path,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+
+	go pb.flushLoop(flushEvery)
+
+	return pb, nil
+}
+
+func openOrCreate[T any](path string, size int) (*CircularBuffer[T], error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return NewCircularBuffer[T](size), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("circularbuffer: open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	return LoadSnapshot[T](f)
+}
+
+func (pb *PersistentBuffer[T]) flushLoop(flushEvery time.Duration) {
+	defer close(pb.done)
+
+	ticker := time.NewTicker(flushEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := pb.flush(); err != nil {
+				fmt.Fprintf(os.Stderr, "circularbuffer: snapshot flush failed: %v\n", err)
+			}
+		case <-pb.stop:
+			return
+		}
+	}
+}
+
+// flush atomically rewrites the snapshot file via a temp file plus rename,
+// so a crash mid-write never leaves a corrupt snapshot behind.
+func (pb *PersistentBuffer[T]) flush() error {
+	tmp := pb.path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("circularbuffer: create temp snapshot: %w", err)
+	}
+
+	if err := pb.SaveSnapshot(f); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("circularbuffer: sync temp snapshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("circularbuffer: close temp snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmp, pb.path); err != nil {
+		return fmt.Errorf("circularbuffer: rename temp snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops the background flush loop, writes one final snapshot, and
+// returns any error from that final flush.
+func (pb *PersistentBuffer[T]) Close() error {
+	close(pb.stop)
+	<-pb.done
+	return pb.flush()
+}
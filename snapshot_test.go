@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	cb := NewCircularBuffer[int](5)
+	for i := 0; i < 7; i++ {
+		cb.Push(i)
+	}
+
+	var buf bytes.Buffer
+	if err := cb.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	restored, err := LoadSnapshot[int](&buf)
+	if err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+
+	got := restored.Items()
+	want := []int{2, 3, 4, 5, 6}
+	if len(got) != len(want) {
+		t.Fatalf("restored items = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("restored items = %v, want %v", got, want)
+		}
+	}
+}
+
+type snapshotEvent struct {
+	Name  string
+	count int // unexported: gob cannot round-trip this
+}
+
+func TestSnapshotRejectsUnexportedFields(t *testing.T) {
+	cb := NewCircularBuffer[snapshotEvent](4)
+	cb.Push(snapshotEvent{Name: "a", count: 1})
+
+	var buf bytes.Buffer
+	err := cb.SaveSnapshot(&buf)
+	if err == nil {
+		t.Fatal("expected SaveSnapshot to reject a type with unexported fields instead of silently dropping them")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("SaveSnapshot wrote %d bytes despite returning an error; it should fail before writing a corrupt snapshot", buf.Len())
+	}
+}
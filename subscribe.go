@@ -0,0 +1,86 @@
+package main
+
+import "sync/atomic"
+
+// subscriber is one channel registered via Subscribe or SubscribeWithReplay.
+type subscriber[T any] struct {
+	ch      chan T
+	dropped atomic.Uint64
+}
+
+// Subscribe returns a channel that receives every item pushed after the
+// call, and a cancel function that unsubscribes and closes the channel.
+// Broadcasting never blocks: if a subscriber's channel is full, the item is
+// dropped and its drop counter (see DroppedCount) is incremented instead.
+func (cb *CircularBuffer[T]) Subscribe() (<-chan T, func()) {
+	return cb.subscribe(false)
+}
+
+// SubscribeWithReplay behaves like Subscribe, but first delivers a replay of
+// the buffer's current contents (tail to head) before any new pushes.
+func (cb *CircularBuffer[T]) SubscribeWithReplay() (<-chan T, func()) {
+	return cb.subscribe(true)
+}
+
+func (cb *CircularBuffer[T]) subscribe(replay bool) (<-chan T, func()) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	sub := &subscriber[T]{ch: make(chan T, cb.size)}
+
+	if replay {
+		for i := 0; i < cb.count; i++ {
+			item := cb.buffer[(cb.tail+i)%cb.size]
+			cb.send(sub, item)
+		}
+	}
+
+	cb.subscribers = append(cb.subscribers, sub)
+
+	cancel := func() {
+		cb.mutex.Lock()
+		defer cb.mutex.Unlock()
+
+		for i, s := range cb.subscribers {
+			if s == sub {
+				cb.subscribers = append(cb.subscribers[:i], cb.subscribers[i+1:]...)
+				close(sub.ch)
+				break
+			}
+		}
+	}
+
+	return sub.ch, cancel
+}
+
+// broadcast delivers item to every current subscriber. The caller must hold cb.mutex.
+func (cb *CircularBuffer[T]) broadcast(item T) {
+	for _, sub := range cb.subscribers {
+		cb.send(sub, item)
+	}
+}
+
+// send makes a single non-blocking delivery attempt, counting a drop on failure.
+func (cb *CircularBuffer[T]) send(sub *subscriber[T], item T) {
+	select {
+	case sub.ch <- item:
+	default:
+		sub.dropped.Add(1)
+	}
+}
+
+// DroppedCount returns how many broadcast items the given subscriber
+// channel has missed because it was full, or 0 if ch is not a channel
+// currently returned by Subscribe/SubscribeWithReplay on this buffer.
+func (cb *CircularBuffer[T]) DroppedCount(ch <-chan T) uint64 {
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+
+	for _, sub := range cb.subscribers {
+		if sub.ch == ch {
+			return sub.dropped.Load()
+		}
+	}
+
+	return 0
+}